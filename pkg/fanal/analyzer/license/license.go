@@ -0,0 +1,24 @@
+package license
+
+import (
+	"context"
+	"io"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/licensing"
+)
+
+// Classify detects and classifies the license of a single file, using the
+// Scanner attached to ctx via licensing.ContextWithScanner. This is the
+// entry point image, filesystem and language analyzers call into, so that
+// every file classified during one scan shares a single pre-loaded backend
+// instead of each call paying its own asset-load cost.
+func Classify(ctx context.Context, filePath string, r io.Reader) (*types.LicenseFile, error) {
+	scanner, err := licensing.ScannerFromContext(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to initialize a license scanner: %w", err)
+	}
+	return scanner.Classify(filePath, r)
+}
@@ -0,0 +1,33 @@
+package license
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/licensing"
+)
+
+type fakeScanner struct {
+	filePath string
+}
+
+func (f *fakeScanner) Classify(filePath string, _ io.Reader) (*types.LicenseFile, error) {
+	f.filePath = filePath
+	return &types.LicenseFile{FilePath: filePath}, nil
+}
+
+func Test_Classify(t *testing.T) {
+	fake := &fakeScanner{}
+	ctx := licensing.ContextWithScanner(context.Background(), fake)
+
+	got, err := Classify(ctx, "LICENSE", strings.NewReader("MIT"))
+	require.NoError(t, err)
+	assert.Equal(t, "LICENSE", got.FilePath)
+	assert.Equal(t, "LICENSE", fake.filePath)
+}
@@ -0,0 +1,166 @@
+package image
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+// dockerConfigFile mirrors the subset of docker's config.json that matters
+// for resolving registry credentials: a static map of base64-encoded
+// "user:pass" entries, plus the credsStore/credHelpers indirection to an
+// external docker-credential-<name> helper.
+type dockerConfigFile struct {
+	Auths       map[string]dockerConfigAuth `json:"auths"`
+	CredsStore  string                      `json:"credsStore"`
+	CredHelpers map[string]string           `json:"credHelpers"`
+}
+
+type dockerConfigAuth struct {
+	Auth string `json:"auth"`
+}
+
+// credHelperKeychain resolves registry credentials the way the docker CLI
+// does: consult a credHelpers/credsStore entry from a config.json (or a
+// forced helper name), falling back to a static `auths` entry, and shelling
+// out to the `docker-credential-<name>` helper protocol when needed.
+type credHelperKeychain struct {
+	configPath string
+	helper     string
+	softFail   bool
+}
+
+// newCredHelperKeychain builds a keychain from option.AuthConfigPath and
+// option.CredentialHelper. It returns false when neither is set, so callers
+// can fall back to authn.DefaultKeychain unchanged.
+func newCredHelperKeychain(option types.DockerOption) (authn.Keychain, bool) {
+	if option.AuthConfigPath == "" && option.CredentialHelper == "" {
+		return nil, false
+	}
+	return credHelperKeychain{
+		configPath: option.AuthConfigPath,
+		helper:     option.CredentialHelper,
+		softFail:   option.AuthSoftFail,
+	}, true
+}
+
+func (k credHelperKeychain) Resolve(target authn.Resource) (authn.Authenticator, error) {
+	registry := target.RegistryStr()
+
+	cfg, err := k.loadConfig()
+	if err != nil {
+		return k.fail(registry, err)
+	}
+
+	helper := k.helper
+	if cfg != nil {
+		if h, ok := cfg.CredHelpers[registry]; ok {
+			helper = h
+		} else if helper == "" {
+			helper = cfg.CredsStore
+		}
+	}
+
+	if helper == "" {
+		if cfg != nil {
+			if a, ok := cfg.Auths[registry]; ok && a.Auth != "" {
+				auth, err := decodeBasicAuth(a.Auth)
+				if err != nil {
+					return k.fail(registry, err)
+				}
+				return auth, nil
+			}
+		}
+		return authn.Anonymous, nil
+	}
+
+	auth, err := getCredentialFromHelper(helper, registry)
+	if err != nil {
+		return k.fail(registry, err)
+	}
+	return auth, nil
+}
+
+// fail returns authn.Anonymous instead of an error when soft-fail is
+// enabled, so that scanning public images doesn't break because a
+// configured helper has no matching entry.
+func (k credHelperKeychain) fail(registry string, err error) (authn.Authenticator, error) {
+	if k.softFail {
+		log.Logger.Debugf("Ignoring auth error for %s: %s", registry, err)
+		return authn.Anonymous, nil
+	}
+	return nil, err
+}
+
+func (k credHelperKeychain) loadConfig() (*dockerConfigFile, error) {
+	if k.configPath == "" {
+		return nil, nil
+	}
+	b, err := os.ReadFile(k.configPath)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to read auth config %q: %w", k.configPath, err)
+	}
+	var cfg dockerConfigFile
+	if err = json.Unmarshal(b, &cfg); err != nil {
+		return nil, xerrors.Errorf("unable to parse auth config %q: %w", k.configPath, err)
+	}
+	return &cfg, nil
+}
+
+// credHelperOutput is the JSON shape written to stdout by a
+// docker-credential-<name> helper's `get` subcommand.
+type credHelperOutput struct {
+	Username string `json:"Username"`
+	Secret   string `json:"Secret"`
+}
+
+// getCredentialFromHelper runs `docker-credential-<helper> get`, feeding the
+// registry host on stdin and parsing the JSON credential back from stdout,
+// per the protocol defined by github.com/docker/docker-credential-helpers.
+func getCredentialFromHelper(helper, registry string) (authn.Authenticator, error) {
+	bin := "docker-credential-" + helper
+	if _, err := exec.LookPath(bin); err != nil {
+		return nil, xerrors.Errorf("credential helper %q not found on PATH: %w", bin, err)
+	}
+
+	cmd := exec.Command(bin, "get")
+	cmd.Stdin = bytes.NewBufferString(registry)
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, xerrors.Errorf("credential helper %q get %q failed: %w", bin, registry, err)
+	}
+
+	var resp credHelperOutput
+	if err = json.Unmarshal(out, &resp); err != nil {
+		return nil, xerrors.Errorf("unable to parse %q output: %w", bin, err)
+	}
+
+	// Helpers that store an identity token (e.g. ECR, ACR) return it as the
+	// secret with the sentinel username "<token>".
+	if resp.Username == "<token>" {
+		return &authn.Bearer{Token: resp.Secret}, nil
+	}
+	return &authn.Basic{Username: resp.Username, Password: resp.Secret}, nil
+}
+
+func decodeBasicAuth(encoded string) (authn.Authenticator, error) {
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to decode auth: %w", err)
+	}
+	userPass := strings.SplitN(string(decoded), ":", 2)
+	if len(userPass) != 2 {
+		return nil, xerrors.Errorf("invalid auth entry")
+	}
+	return &authn.Basic{Username: userPass[0], Password: userPass[1]}, nil
+}
@@ -0,0 +1,123 @@
+package image
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_decodeBasicAuth(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded string
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			encoded: "dXNlcjpwYXNz", // user:pass
+		},
+		{
+			name:    "invalid base64",
+			encoded: "not-base64!!",
+			wantErr: true,
+		},
+		{
+			name:    "missing colon",
+			encoded: "dXNlcnBhc3M=", // userpass
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth, err := decodeBasicAuth(tt.encoded)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			cfg, err := auth.Authorization()
+			require.NoError(t, err)
+			assert.Equal(t, "user", cfg.Username)
+			assert.Equal(t, "pass", cfg.Password)
+		})
+	}
+}
+
+func Test_newCredHelperKeychain(t *testing.T) {
+	tests := []struct {
+		name   string
+		option types.DockerOption
+		wantOK bool
+	}{
+		{
+			name:   "no config",
+			option: types.DockerOption{},
+			wantOK: false,
+		},
+		{
+			name:   "auth config path set",
+			option: types.DockerOption{AuthConfigPath: "/tmp/config.json"},
+			wantOK: true,
+		},
+		{
+			name:   "credential helper set",
+			option: types.DockerOption{CredentialHelper: "ecr-login"},
+			wantOK: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ok := newCredHelperKeychain(tt.option)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func Test_credHelperKeychain_Resolve_staticAuth(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.json")
+	require.NoError(t, os.WriteFile(configPath, []byte(`{
+		"auths": {
+			"registry.example.com": {"auth": "dXNlcjpwYXNz"}
+		}
+	}`), 0o600))
+
+	kc := credHelperKeychain{configPath: configPath}
+	auth, err := kc.Resolve(fakeResource{registry: "registry.example.com"})
+	require.NoError(t, err)
+	cfg, err := auth.Authorization()
+	require.NoError(t, err)
+	assert.Equal(t, "user", cfg.Username)
+	assert.Equal(t, "pass", cfg.Password)
+}
+
+func Test_credHelperKeychain_Resolve_softFail(t *testing.T) {
+	kc := credHelperKeychain{configPath: "/does/not/exist.json", softFail: true}
+	auth, err := kc.Resolve(fakeResource{registry: "registry.example.com"})
+	require.NoError(t, err)
+	cfg, err := auth.Authorization()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Username)
+}
+
+func Test_credHelperKeychain_Resolve_hardFail(t *testing.T) {
+	kc := credHelperKeychain{configPath: "/does/not/exist.json"}
+	_, err := kc.Resolve(fakeResource{registry: "registry.example.com"})
+	assert.Error(t, err)
+}
+
+type fakeResource struct {
+	registry string
+}
+
+func (f fakeResource) String() string {
+	return f.registry
+}
+
+func (f fakeResource) RegistryStr() string {
+	return f.registry
+}
@@ -0,0 +1,37 @@
+package image
+
+import (
+	"context"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+// NewContainerImages resolves imageName to one or more types.Image. It's the
+// entry point the artifact scanner calls into. It reads an on-disk OCI image
+// layout/tar bundle when option.OCILayoutPath is set, and pulls from a remote
+// registry otherwise; an OCI layout error is returned without trying the
+// registry.
+//
+// It returns a slice, not a single image, because option.Platform may select
+// more than one platform (a comma-separated list or "all") for a multi-arch
+// image; single-platform and non-multi-arch images still resolve to a slice
+// of length one, so existing callers that scan images[0] are unaffected.
+func NewContainerImages(ctx context.Context, imageName string, option types.DockerOption) ([]types.Image, error) {
+	if option.OCILayoutPath != "" {
+		img, err := tryOCILayout(imageName, option.OCILayoutPath, option.OCILayoutRef)
+		if err != nil {
+			return nil, xerrors.Errorf("OCI layout error: %w", err)
+		}
+		return []types.Image{img}, nil
+	}
+
+	ref, err := name.ParseReference(imageName)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to parse the image name %q: %w", imageName, err)
+	}
+
+	return tryRemote(ctx, imageName, ref, option)
+}
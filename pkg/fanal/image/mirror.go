@@ -0,0 +1,203 @@
+package image
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/image/token"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+// registriesConfig mirrors the subset of containerd/K3s' registries.yaml
+// schema that Trivy understands: a list of mirror endpoints per registry
+// host, each optionally carrying its own TLS and auth settings.
+type registriesConfig struct {
+	Mirrors map[string]registryMirror `yaml:"mirrors"`
+}
+
+type registryMirror struct {
+	Endpoint []string                   `yaml:"endpoint"`
+	Configs  map[string]registryConfig `yaml:"configs"`
+}
+
+type registryConfig struct {
+	Auth *registryAuthConfig `yaml:"auth"`
+	TLS  *registryTLSConfig  `yaml:"tls"`
+}
+
+type registryAuthConfig struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	Token    string `yaml:"identitytoken"`
+}
+
+type registryTLSConfig struct {
+	CAFile             string `yaml:"ca_file"`
+	CertFile           string `yaml:"cert_file"`
+	KeyFile            string `yaml:"key_file"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+}
+
+func loadRegistriesConfig(path string) (*registriesConfig, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to read registry mirror config %q: %w", path, err)
+	}
+	var cfg registriesConfig
+	if err = yaml.Unmarshal(b, &cfg); err != nil {
+		return nil, xerrors.Errorf("unable to parse registry mirror config %q: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// mirrorEndpoint is a single mirror host paired with the remote.Options
+// needed to talk to it.
+type mirrorEndpoint struct {
+	host string
+	opts []remote.Option
+}
+
+// mirrorEndpointsFor returns, in configured order, the mirror endpoints that
+// should be tried for ref before falling back to its original registry. It
+// returns nil when no mirror config path is set or ref's registry has no
+// mirrors configured.
+func mirrorEndpointsFor(ctx context.Context, ref name.Reference, option types.DockerOption) ([]mirrorEndpoint, error) {
+	if option.RegistryMirrorConfigPath == "" {
+		return nil, nil
+	}
+
+	cfg, err := loadRegistriesConfig(option.RegistryMirrorConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := ref.Context().RegistryStr()
+	mirror, ok := cfg.Mirrors[registry]
+	if !ok && registry == name.DefaultRegistry {
+		// go-containerregistry normalizes Docker Hub to "index.docker.io",
+		// while registries.yaml keys it as "docker.io".
+		mirror, ok = cfg.Mirrors["docker.io"]
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	endpoints := make([]mirrorEndpoint, 0, len(mirror.Endpoint))
+	for _, endpoint := range mirror.Endpoint {
+		// registries.yaml writes `endpoint` as a full URL (e.g.
+		// "https://mirror.example.com") but keys `configs:` by the bare
+		// host, and go-containerregistry's name.* constructors reject a
+		// scheme prefix, so strip it before using the value as either.
+		host := stripScheme(endpoint)
+		opts, err := mirrorRemoteOptions(ctx, host, mirror.Configs[host], option)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, mirrorEndpoint{host: host, opts: opts})
+	}
+	return endpoints, nil
+}
+
+// stripScheme removes a leading "http://" or "https://" from endpoint, as
+// found in a registries.yaml `endpoint` entry, leaving the bare host[:port]
+// used both to address the mirror and to key its `configs:` entry.
+func stripScheme(endpoint string) string {
+	for _, scheme := range []string{"https://", "http://"} {
+		if strings.HasPrefix(endpoint, scheme) {
+			return strings.TrimPrefix(endpoint, scheme)
+		}
+	}
+	return endpoint
+}
+
+// mirrorRemoteOptions builds the TLS and auth remote.Options for a single
+// mirror endpoint, resolving credentials through the same credential-helper
+// path as the primary registry when the endpoint doesn't specify its own.
+func mirrorRemoteOptions(ctx context.Context, host string, cfg registryConfig, option types.DockerOption) ([]remote.Option, error) {
+	var opts []remote.Option
+
+	tlsConfig, err := mirrorTLSConfig(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		opts = append(opts, remote.WithTransport(&http.Transport{TLSClientConfig: tlsConfig}))
+	}
+
+	switch {
+	case cfg.Auth != nil && cfg.Auth.Token != "":
+		opts = append(opts, remote.WithAuth(&authn.Bearer{Token: cfg.Auth.Token}))
+	case cfg.Auth != nil && cfg.Auth.Username != "":
+		opts = append(opts, remote.WithAuth(&authn.Basic{Username: cfg.Auth.Username, Password: cfg.Auth.Password}))
+	default:
+		auth := token.GetToken(ctx, host, option)
+		switch {
+		case auth.Username != "" && auth.Password != "":
+			opts = append(opts, remote.WithAuth(&auth))
+		case option.RegistryToken != "":
+			opts = append(opts, remote.WithAuth(&authn.Bearer{Token: option.RegistryToken}))
+		default:
+			if kc, ok := newCredHelperKeychain(option); ok {
+				opts = append(opts, remote.WithAuthFromKeychain(kc))
+			} else {
+				opts = append(opts, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+			}
+		}
+	}
+
+	return opts, nil
+}
+
+func mirrorTLSConfig(cfg *registryTLSConfig) (*tls.Config, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify}
+
+	if cfg.CAFile != "" {
+		ca, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, xerrors.Errorf("unable to read CA file %q: %w", cfg.CAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, xerrors.Errorf("unable to parse CA file %q", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" && cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, xerrors.Errorf("unable to load client certificate %q/%q: %w", cfg.CertFile, cfg.KeyFile, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// withRegistry rewrites ref to point at registry, keeping its repository
+// path and tag/digest, so a mirror endpoint can be tried without the caller
+// having to rewrite the original image name.
+func withRegistry(ref name.Reference, registry string) (name.Reference, error) {
+	repo := ref.Context().RepositoryStr()
+	switch r := ref.(type) {
+	case name.Tag:
+		return name.NewTag(registry + "/" + repo + ":" + r.TagStr())
+	case name.Digest:
+		return name.NewDigest(registry + "/" + repo + "@" + r.DigestStr())
+	default:
+		return nil, xerrors.Errorf("unsupported reference type %T", ref)
+	}
+}
@@ -0,0 +1,152 @@
+package image
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func Test_loadRegistriesConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "registries.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+mirrors:
+  docker.io:
+    endpoint:
+      - harbor.internal:5000
+    configs:
+      harbor.internal:5000:
+        auth:
+          username: user
+          password: pass
+        tls:
+          insecure_skip_verify: true
+`), 0o600))
+
+	cfg, err := loadRegistriesConfig(path)
+	require.NoError(t, err)
+
+	mirror, ok := cfg.Mirrors["docker.io"]
+	require.True(t, ok)
+	require.Equal(t, []string{"harbor.internal:5000"}, mirror.Endpoint)
+
+	epCfg := mirror.Configs["harbor.internal:5000"]
+	require.NotNil(t, epCfg.Auth)
+	assert.Equal(t, "user", epCfg.Auth.Username)
+	assert.Equal(t, "pass", epCfg.Auth.Password)
+	require.NotNil(t, epCfg.TLS)
+	assert.True(t, epCfg.TLS.InsecureSkipVerify)
+}
+
+func Test_loadRegistriesConfig_missingFile(t *testing.T) {
+	_, err := loadRegistriesConfig("/does/not/exist.yaml")
+	assert.Error(t, err)
+}
+
+func Test_stripScheme(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "https://mirror.example.com", want: "mirror.example.com"},
+		{in: "http://mirror.example.com:5000", want: "mirror.example.com:5000"},
+		{in: "mirror.example.com:5000", want: "mirror.example.com:5000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			assert.Equal(t, tt.want, stripScheme(tt.in))
+		})
+	}
+}
+
+// Test_mirrorEndpointsFor_schemePrefixedEndpoint reproduces a registries.yaml
+// written the way upstream docs show it: `endpoint` as a full URL with a
+// scheme, `configs:` keyed by the bare host. Both the endpoint host and the
+// configs lookup must agree on the scheme-stripped form, or the per-endpoint
+// auth/TLS config silently never matches and withRegistry produces an
+// invalid reference.
+func Test_mirrorEndpointsFor_schemePrefixedEndpoint(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "registries.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+mirrors:
+  docker.io:
+    endpoint:
+      - https://harbor.internal:5000
+    configs:
+      harbor.internal:5000:
+        auth:
+          username: user
+          password: pass
+`), 0o600))
+
+	ref, err := name.ParseReference("docker.io/library/alpine:3.18")
+	require.NoError(t, err)
+
+	endpoints, err := mirrorEndpointsFor(context.Background(), ref, types.DockerOption{RegistryMirrorConfigPath: path})
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "harbor.internal:5000", endpoints[0].host)
+
+	mirrorRef, err := withRegistry(ref, endpoints[0].host)
+	require.NoError(t, err)
+	assert.Equal(t, "harbor.internal:5000/library/alpine:3.18", mirrorRef.Name())
+}
+
+func Test_mirrorTLSConfig(t *testing.T) {
+	t.Run("nil config", func(t *testing.T) {
+		tlsConfig, err := mirrorTLSConfig(nil)
+		require.NoError(t, err)
+		assert.Nil(t, tlsConfig)
+	})
+
+	t.Run("insecure skip verify", func(t *testing.T) {
+		tlsConfig, err := mirrorTLSConfig(&registryTLSConfig{InsecureSkipVerify: true})
+		require.NoError(t, err)
+		assert.True(t, tlsConfig.InsecureSkipVerify)
+	})
+
+	t.Run("missing ca file", func(t *testing.T) {
+		_, err := mirrorTLSConfig(&registryTLSConfig{CAFile: "/does/not/exist.pem"})
+		assert.Error(t, err)
+	})
+}
+
+func Test_withRegistry(t *testing.T) {
+	tests := []struct {
+		name     string
+		ref      string
+		registry string
+		want     string
+	}{
+		{
+			name:     "tag reference",
+			ref:      "docker.io/library/alpine:3.18",
+			registry: "harbor.internal:5000",
+			want:     "harbor.internal:5000/library/alpine:3.18",
+		},
+		{
+			name:     "digest reference",
+			ref:      "docker.io/library/alpine@sha256:b6ca290b6b4cdcca5b3db3ffa338ee0285c11744aa03bd0556172068dcca0b03",
+			registry: "harbor.internal:5000",
+			want:     "harbor.internal:5000/library/alpine@sha256:b6ca290b6b4cdcca5b3db3ffa338ee0285c11744aa03bd0556172068dcca0b03",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := name.ParseReference(tt.ref)
+			require.NoError(t, err)
+
+			mirrorRef, err := withRegistry(ref, tt.registry)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, mirrorRef.Name())
+		})
+	}
+}
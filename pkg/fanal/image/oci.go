@@ -0,0 +1,199 @@
+package image
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+// tryOCILayout builds a types.Image from an on-disk OCI image layout
+// (index.json + blobs/sha256/) or an OCI image-layout tar bundle, such as
+// those produced by `crane pull --format=oci`, `skopeo copy`, ko or kaniko,
+// so air-gapped users can scan images without a daemon or local registry.
+//
+// ref selects a manifest from the index by digest (sha256:...) or by its
+// org.opencontainers.image.ref.name annotation. It may be empty when the
+// index contains exactly one manifest.
+//
+// When path is a tar bundle, it's extracted to a temporary directory that
+// the returned image's Close method removes; callers should Close the image
+// once they're done with it to avoid leaking the extracted layers to disk.
+func tryOCILayout(imageName, path, ref string) (types.Image, error) {
+	dir := path
+	var extractedDir string
+	if isRegularFile(path) {
+		extracted, err := extractOCITar(path)
+		if err != nil {
+			return nil, xerrors.Errorf("unable to extract OCI image-layout tar %q: %w", path, err)
+		}
+		dir = extracted
+		extractedDir = extracted
+	}
+
+	lp, err := layout.FromPath(dir)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to load OCI image layout %q: %w", dir, err)
+	}
+
+	img, err := selectOCIImage(lp, ref)
+	if err != nil {
+		if extractedDir != "" {
+			_ = os.RemoveAll(extractedDir)
+		}
+		return nil, err
+	}
+
+	return ociImage{name: imageName, dir: extractedDir, Image: img}, nil
+}
+
+func isRegularFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// extractOCITar unpacks an OCI image-layout tar (optionally gzip-compressed)
+// bundle to a temporary directory and returns its path.
+func extractOCITar(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", xerrors.Errorf("unable to open %q: %w", path, err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	switch filepath.Ext(path) {
+	case ".gz", ".tgz":
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return "", xerrors.Errorf("unable to open gzip %q: %w", path, err)
+		}
+		defer gr.Close()
+		r = gr
+	}
+
+	// The caller is responsible for removing the returned directory once the
+	// layout.Path backed by it is no longer needed; see ociImage.Close.
+	dir, err := os.MkdirTemp("", "trivy-oci-layout-*")
+	if err != nil {
+		return "", xerrors.Errorf("unable to create temp dir: %w", err)
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", xerrors.Errorf("unable to read tar %q: %w", path, err)
+		}
+
+		target := filepath.Join(dir, filepath.Clean(hdr.Name))
+		// Reject entries that escape dir (e.g. "../../etc/passwd") before
+		// writing anything. An absolute name is joined under dir, not rejected.
+		if target != dir && !strings.HasPrefix(target, dir+string(os.PathSeparator)) {
+			return "", xerrors.Errorf("tar entry %q escapes extraction directory", hdr.Name)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(target, 0o755); err != nil {
+				return "", err
+			}
+		case tar.TypeReg:
+			if err = os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return "", err
+			}
+			if err = writeTarFile(target, tr); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	return dir, nil
+}
+
+func writeTarFile(target string, r io.Reader) error {
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, r)
+	return err
+}
+
+// selectOCIImage picks a single manifest out of the layout's index, by
+// digest or by org.opencontainers.image.ref.name annotation, defaulting to
+// the only manifest present when ref is empty and unambiguous.
+func selectOCIImage(lp layout.Path, ref string) (v1.Image, error) {
+	idx, err := lp.ImageIndex()
+	if err != nil {
+		return nil, xerrors.Errorf("unable to read OCI image index: %w", err)
+	}
+	m, err := idx.IndexManifest()
+	if err != nil {
+		return nil, xerrors.Errorf("unable to read OCI index manifest: %w", err)
+	}
+
+	if ref == "" {
+		if len(m.Manifests) != 1 {
+			return nil, xerrors.Errorf("OCI image layout contains %d manifests, specify one by digest or ref name", len(m.Manifests))
+		}
+		return idx.Image(m.Manifests[0].Digest)
+	}
+
+	for _, desc := range m.Manifests {
+		if desc.Digest.String() == ref || desc.Annotations["org.opencontainers.image.ref.name"] == ref {
+			return idx.Image(desc.Digest)
+		}
+	}
+	return nil, xerrors.Errorf("manifest %q not found in OCI image layout", ref)
+}
+
+type ociImage struct {
+	name string
+	// dir is the extracted tar bundle's temp directory, non-empty only when
+	// tryOCILayout extracted one. Close removes it.
+	dir string
+	v1.Image
+}
+
+func (img ociImage) Name() string {
+	return img.name
+}
+
+func (img ociImage) ID() (string, error) {
+	return ID(img)
+}
+
+func (img ociImage) LayerIDs() ([]string, error) {
+	return LayerIDs(img)
+}
+
+func (img ociImage) RepoTags() []string {
+	return []string{}
+}
+
+func (img ociImage) RepoDigests() []string {
+	return []string{}
+}
+
+// Close removes the temp directory extracted from an OCI tar bundle, if any.
+// It's a no-op for an image loaded directly from an on-disk layout
+// directory.
+func (img ociImage) Close() error {
+	if img.dir == "" {
+		return nil
+	}
+	return os.RemoveAll(img.dir)
+}
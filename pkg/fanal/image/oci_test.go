@@ -0,0 +1,80 @@
+package image
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestTar(t *testing.T, entries map[string]string) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, content := range entries {
+		hdr := &tar.Header{
+			Name: name,
+			Mode: 0o600,
+			Size: int64(len(content)),
+		}
+		require.NoError(t, tw.WriteHeader(hdr))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bundle.tar")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+	return path
+}
+
+func Test_extractOCITar(t *testing.T) {
+	path := writeTestTar(t, map[string]string{
+		"index.json":           `{}`,
+		"blobs/sha256/deadbeef": "content",
+	})
+
+	dir, err := extractOCITar(path)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "blobs/sha256/deadbeef"))
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+}
+
+func Test_extractOCITar_pathTraversal(t *testing.T) {
+	path := writeTestTar(t, map[string]string{
+		"../../../../tmp/evil": "pwned",
+	})
+
+	_, err := extractOCITar(path)
+	assert.Error(t, err)
+}
+
+func Test_ociImage_Close(t *testing.T) {
+	t.Run("extracted tar bundle removes its temp dir", func(t *testing.T) {
+		dir := t.TempDir()
+		img := ociImage{dir: dir}
+
+		require.NoError(t, img.Close())
+
+		_, err := os.Stat(dir)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("layout directory is left alone", func(t *testing.T) {
+		dir := t.TempDir()
+		img := ociImage{dir: ""}
+
+		require.NoError(t, img.Close())
+
+		_, err := os.Stat(dir)
+		assert.NoError(t, err)
+	})
+}
@@ -18,21 +18,52 @@ import (
 	"github.com/aquasecurity/trivy/pkg/log"
 )
 
-func tryRemote(ctx context.Context, imageName string, ref name.Reference, option types.DockerOption) (types.Image, error) {
-	var remoteOpts []remote.Option
+// tryRemote resolves imageName to one types.Image per requested platform.
+// option.Platform may be empty (resolve the default platform, as before a
+// single image), "all" (every platform listed in a multi-arch index), or a
+// comma-separated list of platforms.
+func tryRemote(ctx context.Context, imageName string, ref name.Reference, option types.DockerOption) ([]types.Image, error) {
+	var baseOpts []remote.Option
 	if option.InsecureSkipTLSVerify {
 		t := &http.Transport{
 			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
 		}
-		remoteOpts = append(remoteOpts, remote.WithTransport(t))
+		baseOpts = append(baseOpts, remote.WithTransport(t))
 	}
 
-	if option.Platform != "" {
-		s, err := parsePlatform(ref, option.Platform)
+	platforms, err := parsePlatforms(ref, option.Platform)
+	if err != nil {
+		return nil, err
+	}
+
+	// No explicit platform list: resolve a single image exactly as before.
+	if len(platforms) == 0 {
+		img, err := getRemoteImage(ctx, imageName, ref, option, baseOpts)
 		if err != nil {
 			return nil, err
 		}
-		remoteOpts = append(remoteOpts, remote.WithPlatform(*s))
+		return []types.Image{img}, nil
+	}
+
+	images := make([]types.Image, 0, len(platforms))
+	for _, p := range platforms {
+		platformOpts := append(append([]remote.Option{}, baseOpts...), remote.WithPlatform(p))
+		img, err := getRemoteImage(ctx, imageName, ref, option, platformOpts)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, img)
+	}
+	return images, nil
+}
+
+// getRemoteImage resolves ref to a single types.Image, trying any
+// configured registry mirrors before falling back to the original registry.
+func getRemoteImage(ctx context.Context, imageName string, ref name.Reference, option types.DockerOption, remoteOpts []remote.Option) (types.Image, error) {
+	if img, ok, err := tryRegistryMirrors(ctx, imageName, ref, option, remoteOpts); err != nil {
+		return nil, err
+	} else if ok {
+		return img, nil
 	}
 
 	domain := ref.Context().RegistryStr()
@@ -43,6 +74,11 @@ func tryRemote(ctx context.Context, imageName string, ref name.Reference, option
 	} else if option.RegistryToken != "" {
 		bearer := authn.Bearer{Token: option.RegistryToken}
 		remoteOpts = append(remoteOpts, remote.WithAuth(&bearer))
+	} else if kc, ok := newCredHelperKeychain(option); ok {
+		// A credential helper or a static auth config was explicitly
+		// configured (e.g. for rootless/CI/podman setups), so prefer it over
+		// the ambient DefaultKeychain.
+		remoteOpts = append(remoteOpts, remote.WithAuthFromKeychain(kc))
 	} else {
 		remoteOpts = append(remoteOpts, remote.WithAuthFromKeychain(authn.DefaultKeychain))
 	}
@@ -64,7 +100,112 @@ func tryRemote(ctx context.Context, imageName string, ref name.Reference, option
 		ref:        implicitReference{ref: ref},
 		descriptor: desc,
 	}, nil
+}
+
+// tryRegistryMirrors tries each mirror endpoint configured for ref's
+// registry, in order, and returns the first one that resolves. It returns
+// ok=false, with no error, when no mirrors are configured so the caller can
+// fall through to the original registry unchanged.
+func tryRegistryMirrors(ctx context.Context, imageName string, ref name.Reference, option types.DockerOption, baseOpts []remote.Option) (types.Image, bool, error) {
+	endpoints, err := mirrorEndpointsFor(ctx, ref, option)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, ep := range endpoints {
+		mirrorRef, err := withRegistry(ref, ep.host)
+		if err != nil {
+			log.Logger.Debugf("Skipping registry mirror %s: %s", ep.host, err)
+			continue
+		}
+
+		opts := make([]remote.Option, 0, len(baseOpts)+len(ep.opts))
+		opts = append(opts, baseOpts...)
+		opts = append(opts, ep.opts...)
 
+		desc, err := remote.Get(mirrorRef, opts...)
+		if err != nil {
+			log.Logger.Debugf("Registry mirror %s failed, trying next: %s", ep.host, err)
+			continue
+		}
+		img, err := desc.Image()
+		if err != nil {
+			return nil, false, err
+		}
+		return remoteImage{
+			name:       imageName,
+			Image:      img,
+			ref:        implicitReference{ref: mirrorRef},
+			descriptor: desc,
+		}, true, nil
+	}
+	return nil, false, nil
+}
+
+// parsePlatforms resolves option.Platform into the concrete platforms that
+// should be scanned. An empty string means "default single image" (nil,
+// nil). "all" enumerates every platform in the image's manifest list.
+// Otherwise it's a comma-separated list, each entry resolved the same way a
+// single --platform value always was, including the "*/arch" wildcard.
+func parsePlatforms(ref name.Reference, p string) ([]v1.Platform, error) {
+	if p == "" {
+		return nil, nil
+	}
+	if p == "all" {
+		return allPlatforms(ref)
+	}
+
+	var platforms []v1.Platform
+	for _, each := range strings.Split(p, ",") {
+		platform, err := parsePlatform(ref, strings.TrimSpace(each))
+		if err != nil {
+			return nil, err
+		}
+		if platform == nil {
+			// This entry's "*/arch" wildcard didn't resolve because the image
+			// isn't multi-arch; skip just this entry rather than discarding
+			// platforms already collected from the rest of the list.
+			continue
+		}
+		platforms = append(platforms, *platform)
+	}
+	if len(platforms) == 0 {
+		// None of the requested platforms resolved; fall back to the default
+		// single image instead of returning an empty result.
+		return nil, nil
+	}
+	return platforms, nil
+}
+
+// allPlatforms enumerates every platform listed in ref's manifest index,
+// gracefully degrading to the default single image for schema1/single-arch
+// images.
+func allPlatforms(ref name.Reference) ([]v1.Platform, error) {
+	desc, err := remote.Get(ref)
+	if err != nil {
+		return nil, xerrors.Errorf("remote get error: %w", err)
+	}
+	if !desc.MediaType.IsIndex() {
+		log.Logger.Debug("Ignored --platform=all as the image is not multi-arch")
+		return nil, nil
+	}
+	index, err := desc.ImageIndex()
+	if err != nil {
+		return nil, xerrors.Errorf("remote index error: %w", err)
+	}
+	m, err := index.IndexManifest()
+	if err != nil {
+		return nil, xerrors.Errorf("remote index manifest error: %w", err)
+	}
+
+	var platforms []v1.Platform
+	for _, manifest := range m.Manifests {
+		if manifest.Platform == nil {
+			continue
+		}
+		platforms = append(platforms, *manifest.Platform)
+	}
+	return platforms, nil
 }
 
 func parsePlatform(ref name.Reference, p string) (*v1.Platform, error) {
@@ -0,0 +1,63 @@
+package image
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func Test_parsePlatforms(t *testing.T) {
+	ref, err := name.ParseReference("docker.io/library/alpine:3.18")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		p    string
+		want []v1.Platform
+	}{
+		{
+			name: "empty",
+			p:    "",
+			want: nil,
+		},
+		{
+			name: "single platform",
+			p:    "linux/amd64",
+			want: []v1.Platform{{OS: "linux", Architecture: "amd64"}},
+		},
+		{
+			name: "comma-separated list",
+			p:    "linux/amd64,linux/arm64",
+			want: []v1.Platform{
+				{OS: "linux", Architecture: "amd64"},
+				{OS: "linux", Architecture: "arm64"},
+			},
+		},
+		{
+			name: "comma-separated list with surrounding spaces",
+			p:    "linux/amd64, linux/arm64",
+			want: []v1.Platform{
+				{OS: "linux", Architecture: "amd64"},
+				{OS: "linux", Architecture: "arm64"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePlatforms(ref, tt.p)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_NewContainerImages_invalidName(t *testing.T) {
+	_, err := NewContainerImages(context.Background(), "::not-a-valid-name::", types.DockerOption{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,34 @@
+package types
+
+// LicenseType classifies how a LicenseFinding was matched: as a short
+// license header/SPDX tag, or as the body of a full license file.
+type LicenseType string
+
+const (
+	LicenseTypeHeader LicenseType = "header"
+	LicenseTypeFile   LicenseType = "file"
+)
+
+// LicenseFile is the result of classifying a single file for license
+// content.
+type LicenseFile struct {
+	Type     LicenseType
+	FilePath string
+	Findings []LicenseFinding
+	// SPDXExpression combines every finding sharing the highest confidence
+	// tier into a single SPDX expression (e.g. "MIT OR Apache-2.0"), or is
+	// empty when there are no findings.
+	SPDXExpression string
+}
+
+// LicenseFinding is a single license match within a file.
+type LicenseFinding struct {
+	Name       string
+	Confidence float64
+	Link       string
+	// StartOffset and EndOffset are the byte range, within the scanned
+	// content, that the match covers. They're 0 when a backend doesn't
+	// report a range.
+	StartOffset int
+	EndOffset   int
+}
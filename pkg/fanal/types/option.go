@@ -0,0 +1,48 @@
+package types
+
+// DockerOption represents options for a remote registry, used when
+// resolving an image reference to one or more types.Image.
+type DockerOption struct {
+	// RegistryToken is a static bearer token used instead of resolving
+	// credentials from a keychain.
+	RegistryToken string
+
+	// InsecureSkipTLSVerify skips TLS certificate verification when talking
+	// to a registry.
+	InsecureSkipTLSVerify bool
+
+	// Platform selects which platform(s) to resolve for a multi-arch image.
+	// It may be empty (the default platform), "all", or a comma-separated
+	// list of platforms (e.g. "linux/amd64,linux/arm64").
+	Platform string
+
+	// AuthConfigPath points at a docker config.json / auth.json to resolve
+	// static `auths` entries, or `credHelpers`/`credsStore` indirection to a
+	// docker-credential-<name> helper, from.
+	AuthConfigPath string
+
+	// CredentialHelper forces use of a specific docker-credential-<name>
+	// helper, overriding any credsStore configured via AuthConfigPath.
+	CredentialHelper string
+
+	// AuthSoftFail treats a missing credential-helper entry, or a helper
+	// invocation error, as anonymous access instead of a hard error, so
+	// scanning public images doesn't break because of an unrelated
+	// credential-helper/auth-config setup.
+	AuthSoftFail bool
+
+	// RegistryMirrorConfigPath points at a containerd/K3s-style
+	// registries.yaml describing mirror endpoints to try, in order, before
+	// falling back to the original registry.
+	RegistryMirrorConfigPath string
+
+	// OCILayoutPath points at an on-disk OCI image layout directory
+	// (index.json + blobs/sha256/) or an OCI image-layout tar bundle. When
+	// set, it's used instead of pulling from a remote registry.
+	OCILayoutPath string
+
+	// OCILayoutRef selects a manifest from the OCI layout's index by digest
+	// or by its org.opencontainers.image.ref.name annotation. It may be
+	// empty when the index contains exactly one manifest.
+	OCILayoutRef string
+}
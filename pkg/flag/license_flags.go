@@ -0,0 +1,52 @@
+package flag
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/aquasecurity/trivy/pkg/licensing"
+)
+
+// Flag names for license-scanning CLI options.
+const (
+	FlagLicenseScannerBackend    = "license-scanner-backend"
+	FlagLicenseConfidenceLevel   = "license-confidence-level"
+	FlagLicenseClassifierArchive = "license-classifier-archive"
+)
+
+// LicenseFlagGroup groups the CLI flags that select and tune the license
+// Scanner backend used by image, filesystem and language analyzers.
+type LicenseFlagGroup struct {
+	Backend             string
+	ConfidenceThreshold float64
+	ClassifierArchive   string
+}
+
+// NewLicenseFlagGroup returns a LicenseFlagGroup with its CLI defaults.
+func NewLicenseFlagGroup() *LicenseFlagGroup {
+	return &LicenseFlagGroup{
+		Backend: string(licensing.GoogleLicenseClassifier),
+		// ConfidenceThreshold is left at 0 so licensing.NewScanner applies the
+		// backend-appropriate default instead of baking in one default here
+		// that would be wrong for whichever backend isn't selected.
+	}
+}
+
+// Register binds the license flag group to fs, so it can be parsed as part
+// of a CLI command's flag set.
+func (f *LicenseFlagGroup) Register(fs *pflag.FlagSet) {
+	fs.StringVar(&f.Backend, FlagLicenseScannerBackend, f.Backend,
+		"license scanner backend to use (classifier, licensecheck)")
+	fs.Float64Var(&f.ConfidenceThreshold, FlagLicenseConfidenceLevel, f.ConfidenceThreshold,
+		"minimum confidence (0-1) required to report a license finding; defaults to the selected backend's own default when unset")
+	fs.StringVar(&f.ClassifierArchive, FlagLicenseClassifierArchive, f.ClassifierArchive,
+		"path to a directory of custom license templates for the classifier backend")
+}
+
+// ToLicensingConfig builds a licensing.Config from the flag values.
+func (f *LicenseFlagGroup) ToLicensingConfig() licensing.Config {
+	return licensing.Config{
+		Backend:             licensing.Backend(f.Backend),
+		ConfidenceThreshold: f.ConfidenceThreshold,
+		ClassifierArchive:   f.ClassifierArchive,
+	}
+}
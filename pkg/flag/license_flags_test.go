@@ -0,0 +1,36 @@
+package flag
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/licensing"
+)
+
+func Test_LicenseFlagGroup_Register(t *testing.T) {
+	group := NewLicenseFlagGroup()
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	group.Register(fs)
+
+	require.NoError(t, fs.Parse([]string{
+		"--" + FlagLicenseScannerBackend, string(licensing.GoogleLicenseCheck),
+		"--" + FlagLicenseConfidenceLevel, "0.5",
+		"--" + FlagLicenseClassifierArchive, "/tmp/archive",
+	}))
+
+	assert.Equal(t, string(licensing.GoogleLicenseCheck), group.Backend)
+	assert.Equal(t, 0.5, group.ConfidenceThreshold)
+	assert.Equal(t, "/tmp/archive", group.ClassifierArchive)
+
+	cfg := group.ToLicensingConfig()
+	assert.Equal(t, licensing.GoogleLicenseCheck, cfg.Backend)
+}
+
+func Test_NewLicenseFlagGroup_defaults(t *testing.T) {
+	group := NewLicenseFlagGroup()
+	assert.Equal(t, string(licensing.GoogleLicenseClassifier), group.Backend)
+	assert.Zero(t, group.ConfidenceThreshold)
+}
@@ -0,0 +1,36 @@
+package flag
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+// Flag names for OCI image-layout CLI options.
+const (
+	FlagOCILayoutPath = "input-oci"
+	FlagOCILayoutRef  = "oci-ref"
+)
+
+// OCILayoutFlagGroup groups the CLI flags that select an on-disk OCI image
+// layout or tar bundle as the image source instead of a remote registry.
+type OCILayoutFlagGroup struct {
+	Path string
+	Ref  string
+}
+
+// Register binds the OCI layout flag group to fs, so it can be parsed as
+// part of a CLI command's flag set.
+func (f *OCILayoutFlagGroup) Register(fs *pflag.FlagSet) {
+	fs.StringVar(&f.Path, FlagOCILayoutPath, f.Path,
+		"path to an OCI image layout directory or tar bundle to scan instead of a remote registry")
+	fs.StringVar(&f.Ref, FlagOCILayoutRef, f.Ref,
+		"manifest to select from the OCI image layout's index, by digest or org.opencontainers.image.ref.name; required when the index has more than one manifest")
+}
+
+// Apply sets the OCI layout fields on option, leaving every other field
+// untouched.
+func (f *OCILayoutFlagGroup) Apply(option *types.DockerOption) {
+	option.OCILayoutPath = f.Path
+	option.OCILayoutRef = f.Ref
+}
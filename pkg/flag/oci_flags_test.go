@@ -0,0 +1,30 @@
+package flag
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func Test_OCILayoutFlagGroup_Register(t *testing.T) {
+	group := &OCILayoutFlagGroup{}
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	group.Register(fs)
+
+	require.NoError(t, fs.Parse([]string{
+		"--" + FlagOCILayoutPath, "/tmp/image.tar",
+		"--" + FlagOCILayoutRef, "sha256:deadbeef",
+	}))
+
+	assert.Equal(t, "/tmp/image.tar", group.Path)
+	assert.Equal(t, "sha256:deadbeef", group.Ref)
+
+	var option types.DockerOption
+	group.Apply(&option)
+	assert.Equal(t, "/tmp/image.tar", option.OCILayoutPath)
+	assert.Equal(t, "sha256:deadbeef", option.OCILayoutRef)
+}
@@ -0,0 +1,62 @@
+package flag
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+// Flag names for registry-related CLI options.
+const (
+	FlagInsecure         = "insecure"
+	FlagPlatform         = "platform"
+	FlagRegistryToken    = "registry-token"
+	FlagAuthConfigPath   = "auth-config"
+	FlagCredentialHelper = "credential-helper"
+	FlagAuthSoftFail     = "auth-soft-fail"
+	FlagRegistryMirrors  = "registry-mirrors"
+)
+
+// RegistryFlagGroup groups the CLI flags that control how Trivy
+// authenticates against and resolves container registries.
+type RegistryFlagGroup struct {
+	Insecure                 bool
+	Platform                 string
+	RegistryToken            string
+	AuthConfigPath           string
+	CredentialHelper         string
+	AuthSoftFail             bool
+	RegistryMirrorConfigPath string
+}
+
+// NewRegistryFlagGroup returns a RegistryFlagGroup with its CLI defaults.
+func NewRegistryFlagGroup() *RegistryFlagGroup {
+	return &RegistryFlagGroup{}
+}
+
+// Register binds the registry flag group to fs, so it can be parsed as part
+// of a CLI command's flag set.
+func (f *RegistryFlagGroup) Register(fs *pflag.FlagSet) {
+	fs.BoolVar(&f.Insecure, FlagInsecure, f.Insecure, "allow insecure connections to registries without TLS verification")
+	fs.StringVar(&f.Platform, FlagPlatform, f.Platform,
+		"platform(s) to scan for a multi-arch image, as a comma-separated list of os/arch (or os/arch/variant), or \"all\"")
+	fs.StringVar(&f.RegistryToken, FlagRegistryToken, f.RegistryToken, "registry bearer token")
+	fs.StringVar(&f.AuthConfigPath, FlagAuthConfigPath, f.AuthConfigPath, "path to a Docker config.json for registry authentication")
+	fs.StringVar(&f.CredentialHelper, FlagCredentialHelper, f.CredentialHelper,
+		"name of a docker-credential-* helper to resolve registry credentials, overriding config.json's credsStore (a per-registry credHelpers entry still takes precedence)")
+	fs.BoolVar(&f.AuthSoftFail, FlagAuthSoftFail, f.AuthSoftFail, "fall back to anonymous access when credential resolution fails, instead of erroring")
+	fs.StringVar(&f.RegistryMirrorConfigPath, FlagRegistryMirrors, f.RegistryMirrorConfigPath, "path to a containerd/K3s registries.yaml for registry mirror configuration")
+}
+
+// ToDockerOption builds a types.DockerOption from the flag values.
+func (f *RegistryFlagGroup) ToDockerOption() types.DockerOption {
+	return types.DockerOption{
+		InsecureSkipTLSVerify:    f.Insecure,
+		Platform:                 f.Platform,
+		RegistryToken:            f.RegistryToken,
+		AuthConfigPath:           f.AuthConfigPath,
+		CredentialHelper:         f.CredentialHelper,
+		AuthSoftFail:             f.AuthSoftFail,
+		RegistryMirrorConfigPath: f.RegistryMirrorConfigPath,
+	}
+}
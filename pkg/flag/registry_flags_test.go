@@ -0,0 +1,34 @@
+package flag
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RegistryFlagGroup_Register(t *testing.T) {
+	group := NewRegistryFlagGroup()
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	group.Register(fs)
+
+	require.NoError(t, fs.Parse([]string{
+		"--" + FlagInsecure,
+		"--" + FlagPlatform, "linux/amd64,linux/arm64",
+		"--" + FlagRegistryToken, "token",
+		"--" + FlagAuthConfigPath, "/tmp/config.json",
+		"--" + FlagCredentialHelper, "ecr-login",
+		"--" + FlagAuthSoftFail,
+		"--" + FlagRegistryMirrors, "/tmp/registries.yaml",
+	}))
+
+	option := group.ToDockerOption()
+	assert.True(t, option.InsecureSkipTLSVerify)
+	assert.Equal(t, "linux/amd64,linux/arm64", option.Platform)
+	assert.Equal(t, "token", option.RegistryToken)
+	assert.Equal(t, "/tmp/config.json", option.AuthConfigPath)
+	assert.Equal(t, "ecr-login", option.CredentialHelper)
+	assert.True(t, option.AuthSoftFail)
+	assert.Equal(t, "/tmp/registries.yaml", option.RegistryMirrorConfigPath)
+}
@@ -13,45 +13,61 @@ import (
 	"github.com/aquasecurity/trivy/pkg/log"
 )
 
-var cf *classifier.Classifier
-var classifierOnce sync.Once
+// classifierScanner is the original Scanner backend, built on
+// google/licenseclassifier/v2.
+type classifierScanner struct {
+	cf        *classifier.Classifier
+	threshold float64
+}
+
+// The asset-backed default classifier is expensive to load, so it's
+// initialized once and shared by every scanner that doesn't request a
+// custom archive.
+var (
+	defaultClassifier     *classifier.Classifier
+	defaultClassifierErr  error
+	defaultClassifierOnce sync.Once
+)
+
+func newClassifierScanner(c Config) (Scanner, error) {
+	if c.ClassifierArchive != "" {
+		cf := classifier.NewClassifier(c.ConfidenceThreshold)
+		if err := cf.LoadLicenses(c.ClassifierArchive); err != nil {
+			return nil, xerrors.Errorf("unable to load license archive %q: %w", c.ClassifierArchive, err)
+		}
+		return classifierScanner{cf: cf, threshold: c.ConfidenceThreshold}, nil
+	}
 
-func initGoogleClassifier() error {
-	// Initialize the default classifier once.
-	// This loading is expensive and should be called only when the license classification is needed.
-	var err error
-	classifierOnce.Do(func() {
-		log.Logger.Debug("Loading the the default license classifier...")
-		cf, err = assets.DefaultClassifier()
+	defaultClassifierOnce.Do(func() {
+		log.Logger.Debug("Loading the default license classifier...")
+		defaultClassifier, defaultClassifierErr = assets.DefaultClassifier()
 	})
-	return err
+	if defaultClassifierErr != nil {
+		return nil, defaultClassifierErr
+	}
+	return classifierScanner{cf: defaultClassifier, threshold: c.ConfidenceThreshold}, nil
 }
 
-// Classify detects and classifies the license found in a file
-func Classify(filePath string, r io.Reader) (*types.LicenseFile, error) {
+// Classify detects and classifies the license found in a file.
+func (s classifierScanner) Classify(filePath string, r io.Reader) (*types.LicenseFile, error) {
 	content, err := io.ReadAll(r)
 	if err != nil {
 		return nil, xerrors.Errorf("unable to read a license file %q: %w", filePath, err)
 	}
-	if err = initGoogleClassifier(); err != nil {
-		return nil, err
-	}
 
 	var findings []types.LicenseFinding
 	var matchType types.LicenseType
 	seen := map[string]struct{}{}
 
-	// Use 'github.com/google/licenseclassifier' to find licenses
-	result := cf.Match(cf.Normalize(content))
+	result := s.cf.Match(s.cf.Normalize(content))
 
 	for _, match := range result.Matches {
-		if match.Confidence <= 0.9 {
+		if match.Confidence < s.threshold {
 			continue
 		}
 		if _, ok := seen[match.Name]; ok {
 			continue
 		}
-
 		seen[match.Name] = struct{}{}
 
 		switch match.MatchType {
@@ -68,9 +84,11 @@ func Classify(filePath string, r io.Reader) (*types.LicenseFile, error) {
 			Link:       licenseLink,
 		})
 	}
+
 	return &types.LicenseFile{
-		Type:     matchType,
-		FilePath: filePath,
-		Findings: findings,
+		Type:           matchType,
+		FilePath:       filePath,
+		Findings:       findings,
+		SPDXExpression: spdxExpression(findings),
 	}, nil
 }
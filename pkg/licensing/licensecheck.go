@@ -0,0 +1,62 @@
+package licensing
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/google/licensecheck"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+// licenseCheckScanner is the faster Scanner backend, built on
+// google/licensecheck. Unlike the classifier backend it doesn't load a
+// multi-megabyte corpus up front, at the cost of somewhat coarser matching:
+// it reports a license once its coverage of the scanned text clears a
+// configurable threshold.
+type licenseCheckScanner struct {
+	threshold float64
+}
+
+func newLicenseCheckScanner(c Config) (Scanner, error) {
+	return licenseCheckScanner{threshold: c.ConfidenceThreshold}, nil
+}
+
+// Classify detects and classifies the license found in a file.
+func (s licenseCheckScanner) Classify(filePath string, r io.Reader) (*types.LicenseFile, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to read a license file %q: %w", filePath, err)
+	}
+
+	cov := licensecheck.Scan(content)
+
+	var findings []types.LicenseFinding
+	seen := map[string]struct{}{}
+	for _, m := range cov.Match {
+		confidence := float64(m.End-m.Start) / float64(len(content))
+		if confidence < s.threshold {
+			continue
+		}
+		if _, ok := seen[m.ID]; ok {
+			continue
+		}
+		seen[m.ID] = struct{}{}
+
+		findings = append(findings, types.LicenseFinding{
+			Name:        m.ID,
+			Confidence:  confidence,
+			Link:        fmt.Sprintf("https://spdx.org/licenses/%s.html", m.ID),
+			StartOffset: m.Start,
+			EndOffset:   m.End,
+		})
+	}
+
+	return &types.LicenseFile{
+		Type:           types.LicenseTypeFile,
+		FilePath:       filePath,
+		Findings:       findings,
+		SPDXExpression: spdxExpression(findings),
+	}, nil
+}
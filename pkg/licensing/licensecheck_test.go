@@ -0,0 +1,23 @@
+package licensing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_licenseCheckScanner_Classify(t *testing.T) {
+	s, err := newLicenseCheckScanner(Config{ConfidenceThreshold: 0.9})
+	require.NoError(t, err)
+
+	content := "Permission is hereby granted, free of charge, to any person obtaining a copy of this software"
+	got, err := s.Classify("LICENSE", strings.NewReader(content))
+	require.NoError(t, err)
+
+	for _, f := range got.Findings {
+		assert.Greater(t, f.EndOffset, f.StartOffset)
+		assert.LessOrEqual(t, f.EndOffset, len(content))
+	}
+}
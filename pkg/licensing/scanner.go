@@ -0,0 +1,147 @@
+package licensing
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+// Backend identifies which license-detection engine a Scanner wraps.
+type Backend string
+
+const (
+	// GoogleLicenseClassifier is the original backend, built on
+	// google/licenseclassifier/v2.
+	GoogleLicenseClassifier Backend = "classifier"
+	// GoogleLicenseCheck is a lighter-weight backend, built on
+	// google/licensecheck, that scores matches by text coverage.
+	GoogleLicenseCheck Backend = "licensecheck"
+)
+
+// DefaultConfidenceThreshold is used by the GoogleLicenseCheck backend when a
+// Config doesn't set one.
+const DefaultConfidenceThreshold = 0.75
+
+// defaultClassifierConfidenceThreshold is used by the GoogleLicenseClassifier
+// backend when a Config doesn't set one. It keeps that backend's
+// long-standing 0.9 cutoff, except that a match at exactly 0.9 is now kept,
+// since ConfidenceThreshold is an inclusive minimum. It's kept separate from
+// DefaultConfidenceThreshold so the existing backend's default results don't
+// get noisier.
+const defaultClassifierConfidenceThreshold = 0.9
+
+// Scanner detects and classifies the license(s) found in file content.
+// Implementations load their reference data once and are meant to be
+// reused across calls rather than constructed per file.
+type Scanner interface {
+	Classify(filePath string, r io.Reader) (*types.LicenseFile, error)
+}
+
+// Config selects a Scanner backend and tunes its matching behavior.
+type Config struct {
+	// Backend picks the detection engine. Defaults to GoogleLicenseClassifier.
+	Backend Backend
+	// ConfidenceThreshold is the minimum match confidence (0-1) required to
+	// report a finding. When unset (<= 0), it defaults to
+	// DefaultConfidenceThreshold for GoogleLicenseCheck and to 0.9 for
+	// GoogleLicenseClassifier.
+	ConfidenceThreshold float64
+	// ClassifierArchive optionally points at a directory of custom license
+	// templates, loaded with google/licenseclassifier/v2's
+	// Classifier.LoadLicenses, so proprietary/internal license templates can
+	// be recognized. Only used by the GoogleLicenseClassifier backend.
+	ClassifierArchive string
+}
+
+// NewScanner builds a Scanner for the backend selected in c.
+func NewScanner(c Config) (Scanner, error) {
+	switch c.Backend {
+	case GoogleLicenseCheck:
+		if c.ConfidenceThreshold <= 0 {
+			c.ConfidenceThreshold = DefaultConfidenceThreshold
+		}
+		return newLicenseCheckScanner(c)
+	case GoogleLicenseClassifier, "":
+		if c.ConfidenceThreshold <= 0 {
+			c.ConfidenceThreshold = defaultClassifierConfidenceThreshold
+		}
+		return newClassifierScanner(c)
+	default:
+		return nil, xerrors.Errorf("unknown license scanner backend: %q", c.Backend)
+	}
+}
+
+// defaultScanner backs the package-level Classify compatibility wrapper. Like
+// the default classifierScanner, it's built lazily and shared so existing
+// callers that haven't moved to a context-scoped Scanner yet don't each pay
+// the asset-load cost.
+var (
+	defaultScanner     Scanner
+	defaultScannerErr  error
+	defaultScannerOnce sync.Once
+)
+
+// Classify detects and classifies the license found in a file using the
+// default GoogleLicenseClassifier backend.
+//
+// Deprecated: callers should obtain a Scanner via ScannerFromContext (or
+// NewScanner) and call its Classify method, so that the Scanner used for a
+// scan is chosen and configured once rather than fixed to this default.
+func Classify(filePath string, r io.Reader) (*types.LicenseFile, error) {
+	defaultScannerOnce.Do(func() {
+		defaultScanner, defaultScannerErr = NewScanner(Config{Backend: GoogleLicenseClassifier})
+	})
+	if defaultScannerErr != nil {
+		return nil, defaultScannerErr
+	}
+	return defaultScanner.Classify(filePath, r)
+}
+
+type scannerContextKey struct{}
+
+// ContextWithScanner attaches a pre-loaded Scanner to ctx so that image,
+// filesystem and language analyzers can share one instance instead of each
+// paying the backend's one-time asset-load cost.
+func ContextWithScanner(ctx context.Context, s Scanner) context.Context {
+	return context.WithValue(ctx, scannerContextKey{}, s)
+}
+
+// ScannerFromContext returns the Scanner attached to ctx by
+// ContextWithScanner, or builds the default GoogleLicenseClassifier backend
+// if none was attached.
+func ScannerFromContext(ctx context.Context) (Scanner, error) {
+	if s, ok := ctx.Value(scannerContextKey{}).(Scanner); ok {
+		return s, nil
+	}
+	return NewScanner(Config{Backend: GoogleLicenseClassifier})
+}
+
+// spdxExpression joins the names of every finding that shares the highest
+// confidence tier with " OR ", producing an SPDX expression (e.g.
+// "MIT OR Apache-2.0") when multiple high-confidence licenses overlap, or a
+// single identifier when there's no ambiguity.
+func spdxExpression(findings []types.LicenseFinding) string {
+	if len(findings) == 0 {
+		return ""
+	}
+
+	top := findings[0].Confidence
+	for _, f := range findings[1:] {
+		if f.Confidence > top {
+			top = f.Confidence
+		}
+	}
+
+	var names []string
+	for _, f := range findings {
+		if f.Confidence == top {
+			names = append(names, f.Name)
+		}
+	}
+	return strings.Join(names, " OR ")
+}
@@ -0,0 +1,89 @@
+package licensing
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func Test_spdxExpression(t *testing.T) {
+	tests := []struct {
+		name     string
+		findings []types.LicenseFinding
+		want     string
+	}{
+		{
+			name:     "no findings",
+			findings: nil,
+			want:     "",
+		},
+		{
+			name: "single finding",
+			findings: []types.LicenseFinding{
+				{Name: "MIT", Confidence: 0.95},
+			},
+			want: "MIT",
+		},
+		{
+			name: "multiple findings, one clear winner",
+			findings: []types.LicenseFinding{
+				{Name: "MIT", Confidence: 0.95},
+				{Name: "ISC", Confidence: 0.8},
+			},
+			want: "MIT",
+		},
+		{
+			name: "overlapping high-confidence matches",
+			findings: []types.LicenseFinding{
+				{Name: "MIT", Confidence: 0.95},
+				{Name: "Apache-2.0", Confidence: 0.95},
+			},
+			want: "MIT OR Apache-2.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, spdxExpression(tt.findings))
+		})
+	}
+}
+
+type fakeScanner struct {
+	filePath string
+}
+
+func (f *fakeScanner) Classify(filePath string, _ io.Reader) (*types.LicenseFile, error) {
+	f.filePath = filePath
+	return &types.LicenseFile{FilePath: filePath}, nil
+}
+
+func Test_ScannerFromContext(t *testing.T) {
+	t.Run("no scanner attached", func(t *testing.T) {
+		s, err := ScannerFromContext(context.Background())
+		require.NoError(t, err)
+		assert.IsType(t, classifierScanner{}, s)
+	})
+
+	t.Run("scanner attached", func(t *testing.T) {
+		fake := &fakeScanner{}
+		ctx := ContextWithScanner(context.Background(), fake)
+
+		s, err := ScannerFromContext(ctx)
+		require.NoError(t, err)
+
+		_, err = s.Classify("LICENSE", strings.NewReader(""))
+		require.NoError(t, err)
+		assert.Equal(t, "LICENSE", fake.filePath)
+	})
+}
+
+func Test_NewScanner_unknownBackend(t *testing.T) {
+	_, err := NewScanner(Config{Backend: "bogus"})
+	assert.Error(t, err)
+}